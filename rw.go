@@ -66,29 +66,47 @@ func ReadCsvFile(fn string) (raw [][]string) {
 	return
 }
 
-// NewCsvFile creates a CSV file and returns a Writer object.
-// Returning a writer means the file must be closed externally.
-func NewCsvFile(fn string) *csv.Writer {
+// NewCsvFile creates a CsvFile ready to be written to and returns it.
+// Rows are buffered into a sibling temp file in the same directory, and
+// only replace fn once Flush is called, so a crash mid-write never
+// leaves a partial CSV at fn. If fn already exists, NewCsvFile refuses
+// to proceed unless overwrite is true, in which case the replacement's
+// permissions are taken from the existing file; a brand new file gets
+// 0644.
+func NewCsvFile(fn string, overwrite bool) *CsvFile {
 	fp, err := filepath.Abs(fn)
 	if err != nil {
 		log.Printf("can't abs path to '%s' - %s\n", fn, err)
 		return nil
 	}
+	perm := os.FileMode(0644)
 	if FileExists(fp) {
-		log.Printf("file already exists at '%s'\n", fp)
-		return nil
+		if !overwrite {
+			log.Printf("file already exists at '%s'\n", fp)
+			return nil
+		}
+		if info, err := os.Stat(fp); err == nil {
+			perm = info.Mode().Perm()
+		}
 	}
-	newFile, err := os.Create(fp)
+	tmp, err := os.CreateTemp(filepath.Dir(fp), "."+filepath.Base(fp)+".tmp*")
 	if err != nil {
-		log.Printf("creating '%s' - %s\n", fp, err)
+		log.Printf("creating temp file for '%s' - %s\n", fp, err)
 		return nil
 	}
-	return csv.NewWriter(newFile)
+	if err := tmp.Chmod(perm); err != nil {
+		log.Printf("chmod temp file for '%s' - %s\n", fp, err)
+	}
+	return &CsvFile{
+		Writer: csv.NewWriter(tmp),
+		tmp:    tmp,
+		dest:   fp,
+	}
 }
 
 func CommaSep(fn string, headers []string, values [][]string) {
 	// create the writer
-	cw := NewCsvFile(fn)
+	cw := NewCsvFile(fn, false)
 	if cw == nil {
 		log.Printf("failed to write new csv\n")
 		return
@@ -106,8 +124,11 @@ func CommaSep(fn string, headers []string, values [][]string) {
 			log.Printf("writing row '%d' to csv - %s\n", i, err)
 		}
 	}
-	// write to file
-	cw.Flush()
+	// flush and atomically move into place
+	if err := cw.Flush(); err != nil {
+		log.Printf("flushing csv to '%s' - %s\n", fn, err)
+		return
+	}
 	log.Printf("wrote '%d' lines to '%s'\n", len(values)+1, fn)
 }
 
@@ -172,14 +193,27 @@ func JsonFlat(v interface{}) string {
 	return string(flat)
 }
 
-// Returns a string of indented XML, or a string error
+// Returns a string of indented XML, or a string error. v may be a
+// struct/value to marshal, or a string/[]byte of XML already encoded.
 func XmlPretty(v interface{}) string {
-	raw, err := formatXML([]byte(v.(string)))
+	var raw []byte
+	switch t := v.(type) {
+	case []byte:
+		raw = t
+	case string:
+		raw = []byte(t)
+	default:
+		marshaled, err := xml.Marshal(v)
+		if err != nil {
+			return err.Error()
+		}
+		raw = marshaled
+	}
+	pretty, err := formatXML(raw)
 	if err != nil {
 		return err.Error()
-	} else {
-		return string(raw)
 	}
+	return string(pretty)
 }
 
 // indents the raw XML
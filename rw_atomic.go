@@ -0,0 +1,82 @@
+package rw
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// CsvFile is a *csv.Writer that buffers into a temp file and only
+// replaces its destination on Flush, returned by NewCsvFile.
+type CsvFile struct {
+	*csv.Writer
+	tmp  *os.File
+	dest string
+}
+
+// Flush flushes any buffered rows, closes the underlying temp file, and
+// atomically renames it over the destination path, replacing whatever
+// was there before. Callers must call Flush exactly once when done
+// writing; there is no separate Close.
+func (c *CsvFile) Flush() error {
+	c.Writer.Flush()
+	if err := c.Writer.Error(); err != nil {
+		return err
+	}
+	if err := c.tmp.Close(); err != nil {
+		return fmt.Errorf("closing temp file '%s' - %w", c.tmp.Name(), err)
+	}
+	if err := os.Rename(c.tmp.Name(), c.dest); err != nil {
+		return fmt.Errorf("renaming '%s' to '%s' - %w", c.tmp.Name(), c.dest, err)
+	}
+	return nil
+}
+
+// AtomicWrite writes data to fn without ever leaving a partially-written
+// file at that path: it writes to a sibling temp file
+// (.<name>.tmpXXXX in the same directory), syncs it, and os.Renames it
+// over fn, which is atomic on the same filesystem.
+func AtomicWrite(fn string, data []byte, perm os.FileMode) error {
+	fp, err := filepath.Abs(fn)
+	if err != nil {
+		return fmt.Errorf("can't abs path to '%s' - %w", fn, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(fp), "."+filepath.Base(fp)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("creating temp file for '%s' - %w", fp, err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing temp file for '%s' - %w", fp, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("syncing temp file for '%s' - %w", fp, err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("chmod temp file for '%s' - %w", fp, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temp file for '%s' - %w", fp, err)
+	}
+	if err := os.Rename(tmpName, fp); err != nil {
+		return fmt.Errorf("renaming '%s' to '%s' - %w", tmpName, fp, err)
+	}
+	return nil
+}
+
+// WriteFileBytes is the write counterpart to ReadFileBytes: it atomically
+// writes data to fn, preserving fn's existing permissions if it already
+// exists, or using 0644 for a new file.
+func WriteFileBytes(fn string, data []byte) error {
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(fn); err == nil {
+		perm = info.Mode().Perm()
+	}
+	return AtomicWrite(fn, data, perm)
+}
@@ -0,0 +1,77 @@
+package rw
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+// CsvOptions configures ReadCsvStream. The zero value behaves like the
+// plain csv package defaults: comma-separated, no comment character,
+// strict FieldsPerRecord, and no transcoding. There's no Quote field:
+// encoding/csv hard-codes '"' as the quote character and doesn't expose
+// a way to override it, so there's nothing to plumb through here.
+type CsvOptions struct {
+	// Comma is the field delimiter. Defaults to ',' if zero.
+	Comma rune
+	// Comment, if set, marks lines to ignore, same as csv.Reader.Comment.
+	Comment rune
+	// FieldsPerRecord mirrors csv.Reader.FieldsPerRecord: 0 means "use the
+	// first record's width", negative disables the check entirely.
+	FieldsPerRecord int
+	// Encoding transcodes the file to UTF-8 before parsing, e.g.
+	// golang.org/x/text/encoding/simplifiedchinese.GBK or
+	// golang.org/x/text/encoding/charmap.ISO8859_1. Leave nil for UTF-8 input.
+	Encoding encoding.Encoding
+	// Lenient, when true, logs and skips rows that fail to parse instead
+	// of aborting the stream on the first error.
+	Lenient bool
+}
+
+// ReadCsvStream reads fn row by row, calling cb for each record in order.
+// Unlike ReadCsvFile, it never buffers the whole file in memory, so it's
+// suitable for large or continuously-appended CSVs. Returning an error
+// from the callback stops iteration early and that error is returned.
+func ReadCsvStream(fn string, opts CsvOptions, cb func(row []string) error) error {
+	file, err := os.Open(ValidateFilepath(fn))
+	if err != nil {
+		return fmt.Errorf("opening '%s' - %w", fn, err)
+	}
+	defer file.Close()
+
+	var src io.Reader = file
+	if opts.Encoding != nil {
+		src = transform.NewReader(file, opts.Encoding.NewDecoder())
+	}
+
+	r := csv.NewReader(src)
+	if opts.Comma != 0 {
+		r.Comma = opts.Comma
+	}
+	r.Comment = opts.Comment
+	r.FieldsPerRecord = opts.FieldsPerRecord
+
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if opts.Lenient && errors.As(err, &parseErr) {
+				log.Printf("skipping malformed row in '%s' - %s\n", fn, err)
+				continue
+			}
+			return fmt.Errorf("reading '%s' - %w", fn, err)
+		}
+		if err := cb(row); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package rw
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvField describes one struct field's mapping to a CSV column, derived
+// from its `csv:"name,omitempty"` tag (or its Go name if untagged).
+type csvField struct {
+	index     int
+	name      string
+	omitempty bool
+	layout    string // time.Time layout, from a trailing tag option like `csv:"ts,layout=2006-01-02"`
+}
+
+func csvFieldsOf(t reflect.Type) []csvField {
+	var fields []csvField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		tag := f.Tag.Get("csv")
+		if tag == "-" {
+			continue
+		}
+		cf := csvField{index: i, name: f.Name, layout: time.RFC3339}
+		for j, part := range strings.Split(tag, ",") {
+			if j == 0 && part != "" {
+				cf.name = part
+				continue
+			}
+			if part == "omitempty" {
+				cf.omitempty = true
+			}
+			if strings.HasPrefix(part, "layout=") {
+				cf.layout = strings.TrimPrefix(part, "layout=")
+			}
+		}
+		fields = append(fields, cf)
+	}
+	return fields
+}
+
+// MarshalCSV writes a slice of structs to fn as a CSV file, one row per
+// element, with headers derived from `csv:"name"` struct tags (falling
+// back to the field name). Supported field types are string, the sized
+// int/uint/float kinds, bool, and time.Time (formatted with the tag's
+// `layout=` option, or time.RFC3339 by default).
+func MarshalCSV(v interface{}, fn string) error {
+	sl := reflect.ValueOf(v)
+	if sl.Kind() != reflect.Slice {
+		return fmt.Errorf("MarshalCSV: v must be a slice, got %s", sl.Kind())
+	}
+	elemType := sl.Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("MarshalCSV: slice element must be a struct, got %s", elemType.Kind())
+	}
+	fields := csvFieldsOf(elemType)
+
+	// NewCsvFile buffers into a sibling temp file and only replaces fn on
+	// Flush, so a crash mid-write never leaves a partial CSV at fn.
+	w := NewCsvFile(fn, true)
+	if w == nil {
+		return fmt.Errorf("MarshalCSV: failed to create '%s'", fn)
+	}
+
+	headers := make([]string, len(fields))
+	for i, f := range fields {
+		headers[i] = f.name
+	}
+	if err := w.Write(headers); err != nil {
+		return fmt.Errorf("writing csv headers - %w", err)
+	}
+
+	for i := 0; i < sl.Len(); i++ {
+		row := make([]string, len(fields))
+		item := sl.Index(i)
+		for j, f := range fields {
+			s, err := csvEncodeField(item.Field(f.index), f)
+			if err != nil {
+				return fmt.Errorf("row %d field '%s' - %w", i, f.name, err)
+			}
+			row[j] = s
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("writing row %d - %w", i, err)
+		}
+	}
+	return w.Flush()
+}
+
+func csvEncodeField(val reflect.Value, f csvField) (string, error) {
+	if f.omitempty && val.IsZero() {
+		return "", nil
+	}
+	if val.Type() == reflect.TypeOf(time.Time{}) {
+		t := val.Interface().(time.Time)
+		return t.Format(f.layout), nil
+	}
+	switch val.Kind() {
+	case reflect.String:
+		return val.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(val.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(val.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(val.Float(), 'f', -1, 64), nil
+	case reflect.Bool:
+		return strconv.FormatBool(val.Bool()), nil
+	default:
+		return "", fmt.Errorf("unsupported field type %s", val.Kind())
+	}
+}
+
+// UnmarshalCSV reads fn into v, which must be a pointer to a slice of
+// structs. The first row is treated as a header and matched against
+// `csv:"name"` tags (or field names) to assign columns; unmatched
+// columns are ignored.
+func UnmarshalCSV(fn string, v interface{}) error {
+	ptr := reflect.ValueOf(v)
+	if ptr.Kind() != reflect.Ptr || ptr.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("UnmarshalCSV: v must be a pointer to a slice, got %s", ptr.Kind())
+	}
+	sl := ptr.Elem()
+	elemType := sl.Type().Elem()
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("UnmarshalCSV: slice element must be a struct, got %s", elemType.Kind())
+	}
+	fields := csvFieldsOf(elemType)
+	byName := make(map[string]csvField, len(fields))
+	for _, f := range fields {
+		byName[f.name] = f
+	}
+
+	fp, err := os.Open(ValidateFilepath(fn))
+	if err != nil {
+		return fmt.Errorf("opening '%s' - %w", fn, err)
+	}
+	defer fp.Close()
+
+	r := csv.NewReader(fp)
+	rows, err := r.ReadAll()
+	if err != nil {
+		return fmt.Errorf("reading '%s' - %w", fn, err)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	headers := rows[0]
+
+	for i, row := range rows[1:] {
+		item := reflect.New(elemType).Elem()
+		for col, h := range headers {
+			if col >= len(row) {
+				continue
+			}
+			f, ok := byName[h]
+			if !ok {
+				continue
+			}
+			if err := csvDecodeField(item.Field(f.index), row[col], f); err != nil {
+				return fmt.Errorf("row %d column '%s' - %w", i+1, h, err)
+			}
+		}
+		sl.Set(reflect.Append(sl, item))
+	}
+	return nil
+}
+
+func csvDecodeField(field reflect.Value, raw string, f csvField) error {
+	if raw == "" && f.omitempty {
+		return nil
+	}
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		if raw == "" {
+			return nil
+		}
+		t, err := time.Parse(f.layout, raw)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Kind())
+	}
+	return nil
+}
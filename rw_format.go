@@ -0,0 +1,91 @@
+package rw
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Format identifies a serialization format for Marshal/Unmarshal.
+type Format int
+
+const (
+	FormatJSON Format = iota
+	FormatXML
+	FormatYAML
+	FormatTOML
+	FormatCSV
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	case FormatXML:
+		return "xml"
+	case FormatYAML:
+		return "yaml"
+	case FormatTOML:
+		return "toml"
+	case FormatCSV:
+		return "csv"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
+// Marshal encodes v in the given format, indenting the output when
+// pretty is true. FormatCSV isn't supported here since CSV needs a
+// destination file rather than a byte slice; use MarshalCSV instead.
+func Marshal(v interface{}, f Format, pretty bool) ([]byte, error) {
+	switch f {
+	case FormatJSON:
+		if pretty {
+			return json.MarshalIndent(v, "", "    ")
+		}
+		return json.Marshal(v)
+	case FormatXML:
+		raw, err := xml.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if !pretty {
+			return raw, nil
+		}
+		return formatXML(raw)
+	case FormatYAML:
+		return yaml.Marshal(v)
+	case FormatTOML:
+		buf, err := toml.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return buf, nil
+	case FormatCSV:
+		return nil, fmt.Errorf("Marshal: FormatCSV is not supported, use MarshalCSV")
+	default:
+		return nil, fmt.Errorf("Marshal: unknown format %s", f)
+	}
+}
+
+// Unmarshal decodes data in the given format into v, which must be a
+// pointer. FormatCSV isn't supported here; use UnmarshalCSV instead.
+func Unmarshal(data []byte, f Format, v interface{}) error {
+	switch f {
+	case FormatJSON:
+		return json.Unmarshal(data, v)
+	case FormatXML:
+		return xml.Unmarshal(data, v)
+	case FormatYAML:
+		return yaml.Unmarshal(data, v)
+	case FormatTOML:
+		return toml.Unmarshal(data, v)
+	case FormatCSV:
+		return fmt.Errorf("Unmarshal: FormatCSV is not supported, use UnmarshalCSV")
+	default:
+		return fmt.Errorf("Unmarshal: unknown format %s", f)
+	}
+}
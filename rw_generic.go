@@ -0,0 +1,92 @@
+package rw
+
+import "fmt"
+
+// Contains reports whether query is present in list. It supersedes
+// ExistsInList for any comparable type, not just strings.
+func Contains[T comparable](query T, list []T) bool {
+	for _, l := range list {
+		if l == query {
+			return true
+		}
+	}
+	return false
+}
+
+// AppendUnique appends val to sl unless it's already present, returning
+// the (possibly unchanged) slice. It supersedes AppendIfUnique for any
+// comparable type, not just strings.
+func AppendUnique[T comparable](sl []T, val T) []T {
+	if Contains(val, sl) {
+		return sl
+	}
+	return append(sl, val)
+}
+
+// Join formats each element of list with format (a fmt verb such as
+// "%v" or "%d") and concatenates them separated by sep, with no
+// trailing separator. It supersedes ConcatListNicely for any type and
+// lets the caller control both the separator and the per-element verb.
+func Join[T any](list []T, sep string, format string) string {
+	var res string
+	for i, v := range list {
+		if i > 0 {
+			res += sep
+		}
+		res += fmt.Sprintf(format, v)
+	}
+	return res
+}
+
+// Dedup returns a new slice with duplicate elements removed, preserving
+// the order of first occurrence.
+func Dedup[T comparable](list []T) []T {
+	seen := make(map[T]struct{}, len(list))
+	var res []T
+	for _, v := range list {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		res = append(res, v)
+	}
+	return res
+}
+
+// Diff returns the elements of a that are not present in b, preserving
+// a's order.
+func Diff[T comparable](a, b []T) []T {
+	inB := make(map[T]struct{}, len(b))
+	for _, v := range b {
+		inB[v] = struct{}{}
+	}
+	var res []T
+	for _, v := range a {
+		if _, ok := inB[v]; !ok {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// Intersect returns the elements present in both a and b, preserving
+// a's order and de-duplicating the result.
+func Intersect[T comparable](a, b []T) []T {
+	inB := make(map[T]struct{}, len(b))
+	for _, v := range b {
+		inB[v] = struct{}{}
+	}
+	seen := make(map[T]struct{}, len(a))
+	var res []T
+	for _, v := range a {
+		if _, ok := inB[v]; !ok {
+			continue
+		}
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		res = append(res, v)
+	}
+	return res
+}
@@ -0,0 +1,75 @@
+package rw
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// LineOpts configures LoadLinesOpts. The zero value scans plain lines
+// with the default scanner buffer, dropping blank lines and leaving
+// whitespace untouched; set Trim and KeepBlank to match LoadLines.
+type LineOpts struct {
+	// MaxBufferSize overrides bufio.Scanner's default 64KB token buffer,
+	// needed for files with very long lines. 0 keeps the scanner default.
+	MaxBufferSize int
+	// Split selects the scanner's token boundaries, e.g. bufio.ScanWords
+	// or a custom split func. Defaults to bufio.ScanLines.
+	Split bufio.SplitFunc
+	// KeepBlank, when false, drops empty lines (after Trim is applied)
+	// instead of including them in the result.
+	KeepBlank bool
+	// Trim strips surrounding whitespace from each line when true.
+	Trim bool
+	// Gzip transparently decompresses fn before scanning it.
+	Gzip bool
+}
+
+// LoadLinesOpts reads fn into a slice of lines/tokens under the control
+// of opts, returning any scan or I/O error instead of swallowing it the
+// way LoadLines does. A non-nil err alongside a non-empty lines means
+// the scan was truncated partway through, most often by ErrTooLong when
+// MaxBufferSize is too small for the file's longest line.
+func LoadLinesOpts(fn string, opts LineOpts) (lines []string, err error) {
+	f, err := os.Open(ValidateFilepath(fn))
+	if err != nil {
+		return nil, fmt.Errorf("opening '%s' - %w", fn, err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if opts.Gzip {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("gzip '%s' - %w", fn, err)
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	s := bufio.NewScanner(r)
+	if opts.Split != nil {
+		s.Split(opts.Split)
+	}
+	if opts.MaxBufferSize > 0 {
+		s.Buffer(make([]byte, 0, 64*1024), opts.MaxBufferSize)
+	}
+
+	for s.Scan() {
+		line := s.Text()
+		if opts.Trim {
+			line = strings.TrimSpace(line)
+		}
+		if line == "" && !opts.KeepBlank {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := s.Err(); err != nil {
+		return lines, fmt.Errorf("scanning '%s' - %w", fn, err)
+	}
+	return lines, nil
+}
@@ -0,0 +1,108 @@
+package rw
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+// reverseChunkSize is how much of the file ScanReverse reads per Seek,
+// growing the buffer by this much each time the current chunk doesn't
+// contain a full separator-delimited record.
+const reverseChunkSize = 64 * 1024
+
+// ScanReverse reads fn from the end backwards, splitting on sep and
+// invoking cb with each record in reverse order (last record first),
+// with any trailing sep trimmed off. atEOF is true on the final call,
+// once the beginning of the file has been reached. cb returns false to
+// stop early. This lets "last N lines" style reads skip loading the
+// whole file.
+func ScanReverse(fn string, sep []byte, cb func(line []byte, atEOF bool) bool) error {
+	if len(sep) == 0 {
+		return fmt.Errorf("ScanReverse: sep must not be empty")
+	}
+
+	f, err := os.Open(ValidateFilepath(fn))
+	if err != nil {
+		return fmt.Errorf("opening '%s' - %w", fn, err)
+	}
+	defer f.Close()
+
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("seeking '%s' - %w", fn, err)
+	}
+
+	var (
+		buf      []byte
+		readTo   = size
+		atStart  bool
+		firstRun = true
+	)
+
+	for {
+		chunkLen := int64(reverseChunkSize)
+		if chunkLen > readTo {
+			chunkLen = readTo
+		}
+		readFrom := readTo - chunkLen
+		chunk := make([]byte, chunkLen)
+		if _, err := f.ReadAt(chunk, readFrom); err != nil && err != io.EOF {
+			return fmt.Errorf("reading '%s' - %w", fn, err)
+		}
+		buf = append(chunk, buf...)
+		readTo = readFrom
+		atStart = readTo == 0
+
+		for {
+			idx := bytes.LastIndex(buf, sep)
+			if idx == -1 {
+				if atStart {
+					// an empty first record still counts once a sep was
+					// split off; only a wholly empty file yields no call
+					if len(buf) > 0 || !firstRun {
+						if !cb(buf, true) {
+							return nil
+						}
+					}
+					return nil
+				}
+				break // need a bigger buffer to find the next separator
+			}
+			record := buf[idx+len(sep):]
+			buf = buf[:idx]
+			// a file ending in sep produces an empty trailing record; skip
+			// it once, on the very first split of the very first chunk.
+			if firstRun && len(record) == 0 {
+				firstRun = false
+				continue
+			}
+			firstRun = false
+			if !cb(record, false) {
+				return nil
+			}
+		}
+	}
+}
+
+// TailLines returns the last n lines of fn without reading the whole
+// file into memory, using ScanReverse under the hood.
+func TailLines(fn string, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	var lines []string
+	err := ScanReverse(fn, []byte("\n"), func(line []byte, atEOF bool) bool {
+		lines = append(lines, string(line))
+		return len(lines) < n
+	})
+	if err != nil {
+		return nil, err
+	}
+	// reverse back into forward order
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return lines, nil
+}